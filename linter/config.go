@@ -24,23 +24,52 @@ func AddCommandOptions(cmd *mybase.Command) {
 	cmd.AddOption(mybase.StringOption("errors", 0, "", "Linter problems to treat as fatal errors; see manual for usage"))
 	cmd.AddOption(mybase.StringOption("allow-charset", 0, "latin1,utf8mb4", "Whitelist of acceptable character sets"))
 	cmd.AddOption(mybase.StringOption("allow-engine", 0, "innodb", "Whitelist of acceptable storage engines"))
+	cmd.AddOption(mybase.BoolOption("no-inline-directives", 0, false, "Ignore skeema:lint-* directives embedded in SQL comments"))
+	cmd.AddOption(mybase.StringOption("format", 0, "text", "Output format for lint results; one of text, json, sarif"))
+	cmd.AddOption(mybase.BoolOption("fix", 0, false, "Rewrite .sql files in place using the canonical CREATE statements reported by format notices"))
+	cmd.AddOption(mybase.BoolOption("dry-run", 0, false, "Used with --fix: print a unified diff of the changes that would be made, without writing any files"))
+	cmd.AddOption(mybase.StringOption("fix-only", 0, "", "Used with --fix: comma-separated list of categories to auto-correct, e.g. format"))
+	cmd.AddOption(mybase.StringOption("plugin", 0, "", "Register external lint-rule checkers, as a comma-separated list of name:target pairs, e.g. name:./bin/checker or name:tcp://host:port"))
 }
 
 // Options contains parsed settings controlling linter behavior.
 type Options struct {
-	ProblemSeverity map[string]Severity
-	AllowedCharSets []string
-	AllowedEngines  []string
+	ProblemSeverity    map[string]Severity
+	AllowedCharSets    []string
+	AllowedEngines     []string
+	NoInlineDirectives bool
+	Format             OutputFormat
+	Fix                bool
+	FixDryRun          bool
+	FixOnly            []string
+	ExternalCheckers   []ExternalChecker
 }
 
 // OptionsForDir returns Options based on the configuration in an fs.Dir,
 // effectively converting between mybase options and linter options.
 func OptionsForDir(dir *fs.Dir) (Options, error) {
 	opts := Options{
-		ProblemSeverity: make(map[string]Severity),
-		AllowedCharSets: dir.Config.GetSlice("allow-charset", ',', true),
-		AllowedEngines:  dir.Config.GetSlice("allow-engine", ',', true),
+		ProblemSeverity:    make(map[string]Severity),
+		AllowedCharSets:    dir.Config.GetSlice("allow-charset", ',', true),
+		AllowedEngines:     dir.Config.GetSlice("allow-engine", ',', true),
+		NoInlineDirectives: dir.Config.GetBool("no-inline-directives"),
+		Format:             OutputFormat(strings.ToLower(dir.Config.Get("format"))),
+		Fix:                dir.Config.GetBool("fix"),
+		FixDryRun:          dir.Config.GetBool("dry-run"),
+		FixOnly:            dir.Config.GetSlice("fix-only", ',', true),
 	}
+	if err := validateFormat(opts.Format); err != nil {
+		return opts, err
+	}
+	if err := validateFixOnly(opts.FixOnly); err != nil {
+		return opts, err
+	}
+
+	checkers, err := ParseExternalCheckers(dir.Config.GetSlice("plugin", ',', true))
+	if err != nil {
+		return opts, err
+	}
+	opts.ExternalCheckers = checkers
 
 	allAllowed := strings.Join(allProblemNames(), ", ")
 	for _, val := range dir.Config.GetSlice("warnings", ',', true) {
@@ -61,6 +90,17 @@ func OptionsForDir(dir *fs.Dir) (Options, error) {
 	return opts, nil
 }
 
+// validateFormat returns a ConfigError if f is not one of the supported
+// output formats.
+func validateFormat(f OutputFormat) error {
+	switch f {
+	case FormatText, FormatJSON, FormatSARIF:
+		return nil
+	default:
+		return ConfigError(fmt.Sprintf("Option format must be one of text, json, sarif; found %q", f))
+	}
+}
+
 // ConfigError represents a configuration problem encountered at runtime.
 type ConfigError string
 
@@ -22,6 +22,7 @@ func TestOptionsForDir(t *testing.T) {
 			},
 			AllowedCharSets: []string{"utf8mb4", "utf8"},
 			AllowedEngines:  []string{"myisam"},
+			Format:          FormatText,
 		}
 		if !reflect.DeepEqual(opts, expected) {
 			t.Errorf("OptionsForDir returned %+v, did not match expectation %+v", opts, expected)
@@ -53,6 +54,33 @@ func TestOptionsForDir(t *testing.T) {
 	}
 }
 
+func TestOptionsForDir_Fix(t *testing.T) {
+	dir := getDir(t, "../testdata/linter/options")
+	opts, err := OptionsForDir(dir)
+	if err != nil {
+		t.Fatalf("Unexpected error from OptionsForDir: %s", err)
+	}
+	if opts.Format != FormatJSON {
+		t.Errorf("Expected Format to be %q, instead found %q", FormatJSON, opts.Format)
+	}
+	if !opts.Fix {
+		t.Error("Expected Fix to be true, instead found false")
+	}
+	if !opts.FixDryRun {
+		t.Error("Expected FixDryRun to be true, instead found false")
+	}
+	if expected := []string{"format"}; !reflect.DeepEqual(opts.FixOnly, expected) {
+		t.Errorf("Expected FixOnly to be %v, instead found %v", expected, opts.FixOnly)
+	}
+	if !opts.NoInlineDirectives {
+		t.Error("Expected NoInlineDirectives to be true, instead found false")
+	}
+	expectedCheckers := []ExternalChecker{{Name: "fake", Target: "./checker.sh"}}
+	if !reflect.DeepEqual(opts.ExternalCheckers, expectedCheckers) {
+		t.Errorf("Expected ExternalCheckers to be %+v, instead found %+v", expectedCheckers, opts.ExternalCheckers)
+	}
+}
+
 func getValidConfig(t *testing.T) *mybase.Config {
 	cmd := mybase.NewCommand("lintertest", "", "", nil)
 	AddCommandOptions(cmd)
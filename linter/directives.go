@@ -0,0 +1,203 @@
+package linter
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/skeema/skeema/fs"
+)
+
+// directiveRegexp matches a skeema lint directive embedded in a SQL comment,
+// e.g. "-- skeema:lint-ignore no-pk,bad-charset",
+// "/* skeema:lint-disable bad-engine */", or
+// "-- skeema:lint-severity no-pk=warning". Whether a given directive scopes
+// to a single statement or to the whole file depends on its position; see
+// fileDirectives.
+var directiveRegexp = regexp.MustCompile(`skeema:lint-(ignore|disable|severity)\s+(\S.*?)(?:\*/)?\s*$`)
+
+// directiveSet tracks the suppressions and severity overrides that apply to
+// a single statement, as parsed from its inline lint directives.
+type directiveSet struct {
+	suppressed map[string]bool
+	overrides  map[string]Severity
+}
+
+// mergeDirectiveLine parses a single comment line for a skeema lint
+// directive, adding any suppressions or severity overrides it contains into
+// ds. ds is created lazily and returned, since most lines don't match.
+func mergeDirectiveLine(ds *directiveSet, line string) *directiveSet {
+	matches := directiveRegexp.FindStringSubmatch(line)
+	if matches == nil {
+		return ds
+	}
+	if ds == nil {
+		ds = &directiveSet{
+			suppressed: make(map[string]bool),
+			overrides:  make(map[string]Severity),
+		}
+	}
+	kind, argStr := matches[1], matches[2]
+	for _, field := range strings.Split(argStr, ",") {
+		field = strings.TrimSpace(field)
+		if field == "" {
+			continue
+		}
+		switch kind {
+		case "ignore", "disable":
+			ds.suppressed[strings.ToLower(field)] = true
+		case "severity":
+			name, sev, ok := strings.Cut(field, "=")
+			if !ok {
+				continue
+			}
+			name = strings.ToLower(strings.TrimSpace(name))
+			switch strings.ToLower(strings.TrimSpace(sev)) {
+			case "warning":
+				ds.overrides[name] = SeverityWarning
+			case "error":
+				ds.overrides[name] = SeverityError
+			}
+		}
+	}
+	return ds
+}
+
+// leadingCommentLines returns the run of comment-only lines ("--" or
+// "/* ... */") found at the very beginning of text, stopping at the first
+// line containing anything else. Blank lines within the run are skipped and
+// do not end it.
+func leadingCommentLines(text string) []string {
+	lines, _ := leadingCommentBlock(text)
+	return lines
+}
+
+// leadingCommentPrefix returns the verbatim leading comment block (including
+// any interspersed blank lines and original line terminators) found at the
+// very start of text, or "" if text has no leading comment. Unlike
+// leadingCommentLines, this preserves the original formatting rather than
+// trimmed lines, so callers can splice it back into rewritten SQL (see
+// ApplyFixes in fix.go).
+func leadingCommentPrefix(text string) string {
+	var prefixLen int
+	var sawComment bool
+	for _, line := range strings.SplitAfter(text, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" {
+			prefixLen += len(line)
+			continue
+		}
+		if strings.HasPrefix(trimmed, "--") || (strings.HasPrefix(trimmed, "/*") && strings.HasSuffix(trimmed, "*/")) {
+			prefixLen += len(line)
+			sawComment = true
+			continue
+		}
+		break
+	}
+	if !sawComment {
+		return ""
+	}
+	return text[:prefixLen]
+}
+
+// leadingCommentBlock returns the same comment lines as leadingCommentLines,
+// plus whether that block is detached from the statement's SQL by at least
+// one blank line. A detached comment block was written at the top of the
+// file, independently of the statement that happens to follow it; an
+// attached block was written immediately above that statement, as an
+// annotation of it.
+func leadingCommentBlock(text string) (lines []string, detached bool) {
+	rawLines := strings.Split(text, "\n")
+	lastCommentIdx := -1
+	i := 0
+	for ; i < len(rawLines); i++ {
+		trimmed := strings.TrimSpace(rawLines[i])
+		if trimmed == "" {
+			continue
+		}
+		if strings.HasPrefix(trimmed, "--") || (strings.HasPrefix(trimmed, "/*") && strings.HasSuffix(trimmed, "*/")) {
+			lines = append(lines, trimmed)
+			lastCommentIdx = i
+			continue
+		}
+		break
+	}
+	detached = lastCommentIdx >= 0 && i > lastCommentIdx+1
+	return lines, detached
+}
+
+// statementDirectives parses the statement-scoped lint directives found in
+// the comments immediately preceding stmt's SQL, returning nil if none were
+// present.
+func statementDirectives(stmt *fs.Statement) *directiveSet {
+	var ds *directiveSet
+	for _, line := range leadingCommentLines(stmt.Text) {
+		ds = mergeDirectiveLine(ds, line)
+	}
+	return ds
+}
+
+// fileDirectives determines, for each file, whether that file's first
+// statement is preceded by a directive comment block sitting at the very
+// top of the file -- separated from the statement's SQL by a blank line,
+// rather than written immediately above it. Directives in such a block are
+// file-wide, applying to every statement in the file, not just the one the
+// comment happens to precede. A directive comment written directly above
+// the first statement with no separating blank line is instead scoped to
+// just that statement, the same as for every other statement in the file
+// (see statementDirectives).
+func fileDirectives(logicalSchema *fs.LogicalSchema) map[string]*directiveSet {
+	firstByFile := make(map[string]*fs.Statement)
+	for _, stmt := range logicalSchema.Creates {
+		if cur, ok := firstByFile[stmt.File]; !ok || stmt.Offset < cur.Offset {
+			firstByFile[stmt.File] = stmt
+		}
+	}
+
+	result := make(map[string]*directiveSet)
+	for file, stmt := range firstByFile {
+		lines, detached := leadingCommentBlock(stmt.Text)
+		if !detached {
+			continue
+		}
+		var ds *directiveSet
+		for _, line := range lines {
+			ds = mergeDirectiveLine(ds, line)
+		}
+		if ds != nil {
+			result[file] = ds
+		}
+	}
+	return result
+}
+
+// mergeInto folds src's suppressions and overrides into dst, in-place.
+func mergeInto(dst, src *directiveSet) {
+	for name := range src.suppressed {
+		dst.suppressed[name] = true
+	}
+	for name, sev := range src.overrides {
+		dst.overrides[name] = sev
+	}
+}
+
+// directivesForStatement returns the effective directiveSet for stmt, merging
+// any file-wide directive (from fileDirs, see fileDirectives) with one
+// scoped to stmt itself (which takes precedence on conflicts). fileDirs may
+// be nil.
+func directivesForStatement(stmt *fs.Statement, fileDirs map[string]*directiveSet) *directiveSet {
+	fileLevel := fileDirs[stmt.File]
+	own := statementDirectives(stmt)
+	if fileLevel == nil {
+		return own
+	}
+	if own == nil {
+		return fileLevel
+	}
+	merged := &directiveSet{
+		suppressed: make(map[string]bool),
+		overrides:  make(map[string]Severity),
+	}
+	mergeInto(merged, fileLevel)
+	mergeInto(merged, own)
+	return merged
+}
@@ -0,0 +1,141 @@
+package linter
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/skeema/skeema/fs"
+	"github.com/skeema/tengo"
+)
+
+func TestStatementDirectives(t *testing.T) {
+	stmt := &fs.Statement{
+		File: "foo.sql",
+		Text: "-- skeema:lint-ignore no-pk,bad-charset\nCREATE TABLE foo (id int);",
+	}
+	ds := statementDirectives(stmt)
+	if ds == nil || !ds.suppressed["no-pk"] || !ds.suppressed["bad-charset"] {
+		t.Fatalf("Expected no-pk and bad-charset to be suppressed, instead found %+v", ds)
+	}
+
+	noDirective := &fs.Statement{
+		File: "foo.sql",
+		Text: "CREATE TABLE bar (id int);",
+	}
+	if ds := statementDirectives(noDirective); ds != nil {
+		t.Errorf("Expected nil directiveSet for statement with no directive, instead found %+v", ds)
+	}
+
+	severityStmt := &fs.Statement{
+		File: "foo.sql",
+		Text: "/* skeema:lint-severity no-pk=warning */\nCREATE TABLE baz (id int);",
+	}
+	ds = statementDirectives(severityStmt)
+	if ds == nil || ds.overrides["no-pk"] != SeverityWarning {
+		t.Fatalf("Expected no-pk severity override to warning, instead found %+v", ds)
+	}
+}
+
+// TestDirectivesForStatement_FirstStatementScope confirms that a plain
+// (non-file) directive placed ahead of the first CREATE in a file scopes
+// only to that statement, not to every statement in the file.
+func TestDirectivesForStatement_FirstStatementScope(t *testing.T) {
+	foo := &fs.Statement{
+		File:   "foo.sql",
+		Offset: 0,
+		Text:   "-- skeema:lint-ignore no-pk\nCREATE TABLE foo (id int);",
+	}
+	bar := &fs.Statement{
+		File:   "foo.sql",
+		Offset: 100,
+		Text:   "CREATE TABLE bar (id int, PRIMARY KEY(id));",
+	}
+	logicalSchema := &fs.LogicalSchema{
+		Creates: map[tengo.ObjectKey]*fs.Statement{
+			{Type: tengo.ObjectTypeTable, Name: "foo"}: foo,
+			{Type: tengo.ObjectTypeTable, Name: "bar"}: bar,
+		},
+	}
+	fileDirs := fileDirectives(logicalSchema)
+
+	fooDs := directivesForStatement(foo, fileDirs)
+	if fooDs == nil || !fooDs.suppressed["no-pk"] {
+		t.Errorf("Expected no-pk to be suppressed for foo, instead found %+v", fooDs)
+	}
+	barDs := directivesForStatement(bar, fileDirs)
+	if barDs != nil {
+		t.Errorf("Expected bar to have no directives of its own, instead found %+v", barDs)
+	}
+}
+
+// TestDirectivesForStatement_FileScope confirms that a directive comment
+// block detached from the first statement by a blank line -- i.e. written
+// at the top of the file, rather than immediately above that statement --
+// applies to every statement in the file, including ones other than the one
+// its comment happens to precede.
+func TestDirectivesForStatement_FileScope(t *testing.T) {
+	foo := &fs.Statement{
+		File:   "foo.sql",
+		Offset: 0,
+		Text:   "-- skeema:lint-ignore no-pk\n\nCREATE TABLE foo (id int);",
+	}
+	bar := &fs.Statement{
+		File:   "foo.sql",
+		Offset: 100,
+		Text:   "CREATE TABLE bar (id int, PRIMARY KEY(id));",
+	}
+	logicalSchema := &fs.LogicalSchema{
+		Creates: map[tengo.ObjectKey]*fs.Statement{
+			{Type: tengo.ObjectTypeTable, Name: "foo"}: foo,
+			{Type: tengo.ObjectTypeTable, Name: "bar"}: bar,
+		},
+	}
+	fileDirs := fileDirectives(logicalSchema)
+
+	for name, stmt := range map[string]*fs.Statement{"foo": foo, "bar": bar} {
+		ds := directivesForStatement(stmt, fileDirs)
+		if ds == nil || !ds.suppressed["no-pk"] {
+			t.Errorf("Expected no-pk to be suppressed for %s via file-wide directive, instead found %+v", name, ds)
+		}
+	}
+}
+
+// TestDirectivesForStatement_RealParser confirms the statement- vs.
+// file-scoping behavior above still holds when logicalSchema comes from the
+// actual SQL tokenizer (fs.ParseStatementsInReader), rather than hand-built
+// fs.Statement literals, since statementDirectives and fileDirectives rely
+// on Statement.Text retaining each statement's leading comment verbatim.
+func TestDirectivesForStatement_RealParser(t *testing.T) {
+	sql := "-- skeema:lint-ignore no-pk\nCREATE TABLE foo (id int);\n\nCREATE TABLE bar (id int, PRIMARY KEY(id));\n"
+	logicalSchema := parseLogicalSchema(t, "foo.sql", sql)
+
+	fileDirs := fileDirectives(logicalSchema)
+	foo := logicalSchema.Creates[tengo.ObjectKey{Type: tengo.ObjectTypeTable, Name: "foo"}]
+	bar := logicalSchema.Creates[tengo.ObjectKey{Type: tengo.ObjectTypeTable, Name: "bar"}]
+
+	if ds := directivesForStatement(foo, fileDirs); ds == nil || !ds.suppressed["no-pk"] {
+		t.Errorf("Expected no-pk to be suppressed for foo, instead found %+v", ds)
+	}
+	if ds := directivesForStatement(bar, fileDirs); ds != nil {
+		t.Errorf("Expected bar to have no directives of its own, instead found %+v", ds)
+	}
+}
+
+func TestMergeInto(t *testing.T) {
+	dst := &directiveSet{
+		suppressed: map[string]bool{"no-pk": true},
+		overrides:  map[string]Severity{"bad-engine": SeverityWarning},
+	}
+	src := &directiveSet{
+		suppressed: map[string]bool{"bad-charset": true},
+		overrides:  map[string]Severity{"no-pk": SeverityError},
+	}
+	mergeInto(dst, src)
+	expected := &directiveSet{
+		suppressed: map[string]bool{"no-pk": true, "bad-charset": true},
+		overrides:  map[string]Severity{"bad-engine": SeverityWarning, "no-pk": SeverityError},
+	}
+	if !reflect.DeepEqual(dst, expected) {
+		t.Errorf("mergeInto produced %+v, expected %+v", dst, expected)
+	}
+}
@@ -0,0 +1,67 @@
+package linter
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/skeema/skeema/fs"
+	"github.com/skeema/skeema/workspace"
+	"github.com/skeema/tengo"
+)
+
+// LintFiles lints the SQL found in one or more specific files or glob
+// patterns, rather than requiring an entire configured directory. This
+// mirrors how modern formatters and linters accept a file list directly,
+// enabling editor/LSP integrations and pre-commit hooks that only want to
+// check changed files. Use LintReader, or pass "-" as a path, to lint SQL
+// from stdin instead.
+func LintFiles(paths []string, wsOpts workspace.Options, opts Options) *Result {
+	result := &Result{}
+	for _, path := range paths {
+		if path == "-" {
+			result.Merge(LintReader(os.Stdin, path, wsOpts, opts))
+			continue
+		}
+		matches, err := filepath.Glob(path)
+		if err != nil {
+			result.Exceptions = append(result.Exceptions, fmt.Errorf("Invalid glob %s: %w", path, err))
+			continue
+		}
+		if len(matches) == 0 {
+			matches = []string{path} // no glob match; let os.Open surface a not-found error below
+		}
+		for _, match := range matches {
+			f, err := os.Open(match)
+			if err != nil {
+				result.Exceptions = append(result.Exceptions, err)
+				continue
+			}
+			result.Merge(LintReader(f, match, wsOpts, opts))
+			f.Close()
+		}
+	}
+	return result
+}
+
+// LintReader lints the SQL read from r, using filename to populate file
+// location information in any resulting Annotations. filename may be "-"
+// when r is not backed by a real file, e.g. os.Stdin.
+func LintReader(r io.Reader, filename string, wsOpts workspace.Options, opts Options) *Result {
+	statements, err := fs.ParseStatementsInReader(filename, r)
+	if err != nil {
+		return BadConfigResult(fmt.Errorf("Unable to parse %s: %w", filename, err))
+	}
+
+	logicalSchema := &fs.LogicalSchema{
+		Creates: make(map[tengo.ObjectKey]*fs.Statement),
+	}
+	for _, stmt := range statements {
+		if stmt.Type == fs.StatementTypeCreate {
+			logicalSchema.Creates[stmt.ObjectKey()] = stmt
+		}
+	}
+
+	return lintLogicalSchema(filename, logicalSchema, opts, wsOpts, nil)
+}
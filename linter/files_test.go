@@ -0,0 +1,100 @@
+package linter
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/skeema/skeema/fs"
+	"github.com/skeema/skeema/workspace"
+	"github.com/skeema/tengo"
+)
+
+// parseLogicalSchema parses sql with the real SQL tokenizer, the same way
+// LintReader does, and returns the resulting fs.LogicalSchema. It lets tests
+// exercise directive-scoping and --fix logic against genuine fs.Statement
+// values -- with real File/LineNo/CharNo/Offset info -- instead of hand-built
+// literals that merely assume how the tokenizer lays those fields out.
+func parseLogicalSchema(t *testing.T, filename, sql string) *fs.LogicalSchema {
+	t.Helper()
+	statements, err := fs.ParseStatementsInReader(filename, strings.NewReader(sql))
+	if err != nil {
+		t.Fatalf("Unexpected error parsing %s: %v", filename, err)
+	}
+	logicalSchema := &fs.LogicalSchema{
+		Creates: make(map[tengo.ObjectKey]*fs.Statement),
+	}
+	for _, stmt := range statements {
+		if stmt.Type == fs.StatementTypeCreate {
+			logicalSchema.Creates[stmt.ObjectKey()] = stmt
+		}
+	}
+	return logicalSchema
+}
+
+func TestLintFilesMissingFile(t *testing.T) {
+	result := LintFiles([]string{"../testdata/linter/does-not-exist.sql"}, workspace.Options{}, Options{})
+	if len(result.Exceptions) != 1 {
+		t.Fatalf("Expected 1 exception for a missing file, instead found %d", len(result.Exceptions))
+	}
+}
+
+func TestLintFilesInvalidGlob(t *testing.T) {
+	result := LintFiles([]string{"["}, workspace.Options{}, Options{})
+	if len(result.Exceptions) != 1 {
+		t.Fatalf("Expected 1 exception for an invalid glob, instead found %d", len(result.Exceptions))
+	}
+}
+
+// TestLintReaderParsesMultiStatementFixture is a fixture-backed happy-path
+// test of the parsing stage that LintReader (and therefore LintFiles) builds
+// its logicalSchema from: it confirms fs.ParseStatementsInReader assigns the
+// File/LineNo/CharNo/Offset info that directive scoping (fileDirectives,
+// directivesForStatement) and --fix (ApplyFixes) both depend on. The
+// remainder of the pipeline -- diffing against a real schema to produce
+// Errors/Warnings/FormatNotices -- requires a live MySQL instance via
+// workspace.ExecLogicalSchema, the same as every problem check in this
+// package, and so isn't exercised by this package's unit tests.
+func TestLintReaderParsesMultiStatementFixture(t *testing.T) {
+	sql := "CREATE TABLE foo (\n  id int\n);\n\n" +
+		"-- skeema:lint-ignore no-pk\nCREATE TABLE bar (\n  id int\n);\n"
+	logicalSchema := parseLogicalSchema(t, "fixture.sql", sql)
+
+	foo := logicalSchema.Creates[tengo.ObjectKey{Type: tengo.ObjectTypeTable, Name: "foo"}]
+	if foo == nil {
+		t.Fatal("Expected a CREATE statement for foo, instead found none")
+	}
+	if foo.File != "fixture.sql" {
+		t.Errorf("Expected foo.File to be fixture.sql, instead found %q", foo.File)
+	}
+	if foo.LineNo != 1 || foo.CharNo != 1 {
+		t.Errorf("Expected foo to start at line 1 char 1, instead found line %d char %d", foo.LineNo, foo.CharNo)
+	}
+	if foo.Offset != 0 {
+		t.Errorf("Expected foo.Offset to be 0, instead found %d", foo.Offset)
+	}
+
+	bar := logicalSchema.Creates[tengo.ObjectKey{Type: tengo.ObjectTypeTable, Name: "bar"}]
+	if bar == nil {
+		t.Fatal("Expected a CREATE statement for bar, instead found none")
+	}
+	if bar.File != "fixture.sql" {
+		t.Errorf("Expected bar.File to be fixture.sql, instead found %q", bar.File)
+	}
+	if bar.LineNo != 5 {
+		t.Errorf("Expected bar to start at line 5, instead found line %d", bar.LineNo)
+	}
+	if want := sql[bar.Offset : bar.Offset+len(bar.Text)]; want != bar.Text {
+		t.Errorf("Expected bar.Offset/Text to reproduce its span of sql, instead found %q", want)
+	}
+}
+
+func TestLintReaderParseError(t *testing.T) {
+	r := strings.NewReader("CREATE TABLE foo (")
+	result := LintReader(r, "foo.sql", workspace.Options{}, Options{})
+	if len(result.Exceptions) != 1 {
+		t.Fatalf("Expected 1 exception for unparseable SQL, instead found %d", len(result.Exceptions))
+	}
+	if _, ok := result.Exceptions[0].(ConfigError); !ok {
+		t.Errorf("Expected exception to be a ConfigError, instead found %T", result.Exceptions[0])
+	}
+}
@@ -0,0 +1,273 @@
+package linter
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// fixCategoryFormat is the FixOnly category covering FormatNotices, i.e.
+// rewriting statements to match their canonical DB rendering. It is
+// currently the only supported category; see fixCategories.
+const fixCategoryFormat = "format"
+
+// fixCategories lists the values accepted by the fix-only option.
+var fixCategories = []string{fixCategoryFormat}
+
+// validateFixOnly returns a ConfigError if raw contains any value that isn't
+// a known fix category.
+func validateFixOnly(raw []string) error {
+	for _, val := range raw {
+		var found bool
+		for _, known := range fixCategories {
+			if strings.EqualFold(val, known) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return ConfigError(fmt.Sprintf("Option fix-only must be a comma-separated list including these values: %s", strings.Join(fixCategories, ", ")))
+		}
+	}
+	return nil
+}
+
+// fixEnabled returns true if category is permitted to be auto-corrected,
+// given the (possibly empty) FixOnly filter in opts.
+func fixEnabled(opts Options, category string) bool {
+	if len(opts.FixOnly) == 0 {
+		return true
+	}
+	for _, allowed := range opts.FixOnly {
+		if strings.EqualFold(allowed, category) {
+			return true
+		}
+	}
+	return false
+}
+
+// ApplyFixes rewrites the .sql files referenced by r's FormatNotices to use
+// their canonical CREATE statement text, honoring opts.FixDryRun and
+// opts.FixOnly. When opts.FixDryRun is true, a unified diff of the changes
+// is written to w instead of touching any files.
+func ApplyFixes(w io.Writer, r *Result, opts Options) error {
+	if !opts.Fix || !fixEnabled(opts, fixCategoryFormat) || len(r.FormatNotices) == 0 {
+		return nil
+	}
+
+	byFile := make(map[string][]*Annotation)
+	for _, ann := range r.FormatNotices {
+		file := ann.Statement.File
+		byFile[file] = append(byFile[file], ann)
+	}
+
+	files := make([]string, 0, len(byFile))
+	for file := range byFile {
+		files = append(files, file)
+	}
+	sort.Strings(files)
+
+	for _, file := range files {
+		anns := byFile[file]
+		// Replace from the end of the file backwards, so that earlier
+		// statements' offsets remain valid as later ones are rewritten.
+		sort.Slice(anns, func(i, j int) bool {
+			return anns[i].Statement.Offset > anns[j].Statement.Offset
+		})
+
+		original, err := ioutil.ReadFile(file)
+		if err != nil {
+			return fmt.Errorf("fix: unable to read %s: %w", file, err)
+		}
+		rewritten := make([]byte, len(original))
+		copy(rewritten, original)
+		for _, ann := range anns {
+			stmt := ann.Statement
+			start := stmt.Offset
+			end := start + len(stmt.Text)
+			if start < 0 || end > len(rewritten) {
+				return fmt.Errorf("fix: statement offset out of bounds in %s", file)
+			}
+			// stmt.Text's span may include a leading comment (e.g. a lint
+			// directive) that ann.Message, built purely from the canonical
+			// DB rendering, knows nothing about. Re-prepend it so --fix never
+			// silently deletes a comment sitting above the statement it fixes.
+			replacement := ann.Message
+			if prefix := leadingCommentPrefix(stmt.Text); prefix != "" && !strings.HasPrefix(replacement, prefix) {
+				replacement = prefix + replacement
+			}
+			rewritten = append(rewritten[:start:start], append([]byte(replacement), rewritten[end:]...)...)
+		}
+
+		if opts.FixDryRun {
+			writeUnifiedDiff(w, file, string(original), string(rewritten))
+			continue
+		}
+		if err := writeFileAtomically(file, rewritten); err != nil {
+			return fmt.Errorf("fix: unable to write %s: %w", file, err)
+		}
+	}
+	return nil
+}
+
+// writeFileAtomically writes contents to path by first writing to a
+// temporary file in the same directory, then renaming it into place.
+func writeFileAtomically(path string, contents []byte) error {
+	dir := filepath.Dir(path)
+	tmp, err := ioutil.TempFile(dir, filepath.Base(path)+".tmp")
+	if err != nil {
+		return err
+	}
+	tmpPath := tmp.Name()
+	defer os.Remove(tmpPath)
+
+	if _, err := tmp.Write(contents); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	info, err := os.Stat(path)
+	if err == nil {
+		os.Chmod(tmpPath, info.Mode())
+	}
+	return os.Rename(tmpPath, path)
+}
+
+// diffContext is the number of unchanged lines of context shown around each
+// change in writeUnifiedDiff's output, matching the default used by `diff
+// -u` and `git diff`.
+const diffContext = 3
+
+// diffOp is a single line-level edit produced by lcsDiff.
+type diffOp struct {
+	kind byte // ' ' (context), '-' (deletion), or '+' (addition)
+	text string
+}
+
+// lcsDiff computes a minimal line-level diff between a and b, via the
+// standard longest-common-subsequence dynamic programming table. This is
+// adequate for the schema files skeema operates on; it is not intended for
+// arbitrarily large inputs.
+func lcsDiff(a, b []string) []diffOp {
+	n, m := len(a), len(b)
+	lcs := make([][]int, n+1)
+	for i := range lcs {
+		lcs[i] = make([]int, m+1)
+	}
+	for i := n - 1; i >= 0; i-- {
+		for j := m - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else if lcs[i+1][j] >= lcs[i][j+1] {
+				lcs[i][j] = lcs[i+1][j]
+			} else {
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+
+	ops := make([]diffOp, 0, n+m)
+	i, j := 0, 0
+	for i < n && j < m {
+		switch {
+		case a[i] == b[j]:
+			ops = append(ops, diffOp{' ', a[i]})
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			ops = append(ops, diffOp{'-', a[i]})
+			i++
+		default:
+			ops = append(ops, diffOp{'+', b[j]})
+			j++
+		}
+	}
+	for ; i < n; i++ {
+		ops = append(ops, diffOp{'-', a[i]})
+	}
+	for ; j < m; j++ {
+		ops = append(ops, diffOp{'+', b[j]})
+	}
+	return ops
+}
+
+// writeUnifiedDiff prints a standard unified diff (complete with @@ hunk
+// headers and surrounding context lines, as produced by `diff -u` or `git
+// diff`) of the line-level changes between before and after to w, labeled
+// with file.
+func writeUnifiedDiff(w io.Writer, file, before, after string) {
+	// A trailing newline terminates the last line rather than beginning an
+	// additional empty one.
+	if strings.HasSuffix(before, "\n") && strings.HasSuffix(after, "\n") {
+		before, after = before[:len(before)-1], after[:len(after)-1]
+	}
+	ops := lcsDiff(strings.Split(before, "\n"), strings.Split(after, "\n"))
+
+	// Find each contiguous run of changed lines, then expand it by
+	// diffContext lines of context on either side, merging any runs whose
+	// expanded ranges overlap.
+	var hunks [][2]int // half-open [start, end) ranges of indices into ops
+	for i := 0; i < len(ops); {
+		if ops[i].kind == ' ' {
+			i++
+			continue
+		}
+		start := i
+		for i < len(ops) && ops[i].kind != ' ' {
+			i++
+		}
+		lo, hi := start-diffContext, i+diffContext
+		if lo < 0 {
+			lo = 0
+		}
+		if hi > len(ops) {
+			hi = len(ops)
+		}
+		if len(hunks) > 0 && lo <= hunks[len(hunks)-1][1] {
+			hunks[len(hunks)-1][1] = hi
+		} else {
+			hunks = append(hunks, [2]int{lo, hi})
+		}
+	}
+	if len(hunks) == 0 {
+		return
+	}
+
+	fmt.Fprintf(w, "--- %s\n+++ %s\n", file, file)
+
+	aLine, bLine, opIdx := 0, 0, 0
+	advance := func(upTo int) {
+		for ; opIdx < upTo; opIdx++ {
+			if ops[opIdx].kind != '+' {
+				aLine++
+			}
+			if ops[opIdx].kind != '-' {
+				bLine++
+			}
+		}
+	}
+	for _, hunk := range hunks {
+		advance(hunk[0])
+		hunkOps := ops[hunk[0]:hunk[1]]
+		var aCount, bCount int
+		for _, op := range hunkOps {
+			if op.kind != '+' {
+				aCount++
+			}
+			if op.kind != '-' {
+				bCount++
+			}
+		}
+		fmt.Fprintf(w, "@@ -%d,%d +%d,%d @@\n", aLine+1, aCount, bLine+1, bCount)
+		for _, op := range hunkOps {
+			fmt.Fprintf(w, "%c%s\n", op.kind, op.text)
+		}
+		advance(hunk[1])
+	}
+}
@@ -0,0 +1,230 @@
+package linter
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/skeema/skeema/fs"
+	"github.com/skeema/tengo"
+)
+
+func TestValidateFixOnly(t *testing.T) {
+	if err := validateFixOnly(nil); err != nil {
+		t.Errorf("Unexpected error for nil FixOnly: %v", err)
+	}
+	if err := validateFixOnly([]string{"format"}); err != nil {
+		t.Errorf("Unexpected error for valid FixOnly: %v", err)
+	}
+	if err := validateFixOnly([]string{"charset"}); err == nil {
+		t.Error("Expected error for unsupported FixOnly category, instead found nil")
+	} else if _, ok := err.(ConfigError); !ok {
+		t.Errorf("Expected ConfigError, instead got %T", err)
+	}
+}
+
+func TestApplyFixesRewritesFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "foo.sql")
+	stmtText := "CREATE TABLE foo (\n  id int\n);"
+	original := stmtText + "\n"
+	if err := os.WriteFile(path, []byte(original), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	stmt := &fs.Statement{File: path, Offset: 0, Text: stmtText}
+	canonical := "CREATE TABLE `foo` (\n  `id` int\n)"
+	result := &Result{
+		FormatNotices: []*Annotation{{
+			Statement: stmt,
+			Message:   canonical,
+		}},
+	}
+	opts := Options{Fix: true}
+
+	var buf bytes.Buffer
+	if err := ApplyFixes(&buf, result, opts); err != nil {
+		t.Fatalf("Unexpected error from ApplyFixes: %v", err)
+	}
+
+	rewritten, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := canonical + "\n"; string(rewritten) != expected {
+		t.Errorf("Expected rewritten file to equal %q, instead found %q", expected, string(rewritten))
+	}
+}
+
+func TestApplyFixesDryRun(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "foo.sql")
+	stmtText := "CREATE TABLE foo (id int);"
+	if err := os.WriteFile(path, []byte(stmtText+"\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	stmt := &fs.Statement{File: path, Offset: 0, Text: stmtText}
+	canonical := "CREATE TABLE `foo` (`id` int)"
+	result := &Result{
+		FormatNotices: []*Annotation{{Statement: stmt, Message: canonical}},
+	}
+	opts := Options{Fix: true, FixDryRun: true}
+
+	var buf bytes.Buffer
+	if err := ApplyFixes(&buf, result, opts); err != nil {
+		t.Fatalf("Unexpected error from ApplyFixes: %v", err)
+	}
+
+	rewritten, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(rewritten) != stmtText+"\n" {
+		t.Error("Dry-run should not have modified the file on disk")
+	}
+
+	diff := buf.String()
+	if !strings.HasPrefix(diff, "--- "+path+"\n+++ "+path+"\n") {
+		t.Errorf("Expected diff to start with file headers, instead got: %s", diff)
+	}
+	if !strings.Contains(diff, "@@ ") {
+		t.Errorf("Expected diff to contain a hunk header, instead got: %s", diff)
+	}
+	if !strings.Contains(diff, "-"+stmtText) || !strings.Contains(diff, "+"+canonical) {
+		t.Errorf("Expected diff to show removed and added lines, instead got: %s", diff)
+	}
+}
+
+func TestApplyFixesFixOnlyFilter(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "foo.sql")
+	stmtText := "CREATE TABLE foo (id int);"
+	if err := os.WriteFile(path, []byte(stmtText+"\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	stmt := &fs.Statement{File: path, Offset: 0, Text: stmtText}
+	result := &Result{
+		FormatNotices: []*Annotation{{Statement: stmt, Message: "CREATE TABLE `foo` (`id` int)"}},
+	}
+	opts := Options{Fix: true, FixOnly: []string{"something-else"}}
+
+	var buf bytes.Buffer
+	if err := ApplyFixes(&buf, result, opts); err != nil {
+		t.Fatalf("Unexpected error from ApplyFixes: %v", err)
+	}
+	rewritten, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(rewritten) != stmtText+"\n" {
+		t.Error("Expected file to be untouched when fix-only excludes the format category")
+	}
+}
+
+func TestLeadingCommentPrefix(t *testing.T) {
+	if prefix := leadingCommentPrefix("CREATE TABLE foo (id int);"); prefix != "" {
+		t.Errorf("Expected no leading comment, instead found %q", prefix)
+	}
+	text := "-- skeema:lint-ignore no-pk\nCREATE TABLE foo (id int);"
+	if prefix := leadingCommentPrefix(text); prefix != "-- skeema:lint-ignore no-pk\n" {
+		t.Errorf("Expected leading comment %q, instead found %q", "-- skeema:lint-ignore no-pk\n", prefix)
+	}
+}
+
+// TestApplyFixesPreservesLeadingComment confirms that fixing a statement
+// whose fs.Statement.Text span includes a leading lint-directive comment
+// does not delete that comment, since the replacement text supplied via
+// FormatNotices' Message is built purely from the canonical DB rendering and
+// knows nothing about it.
+func TestApplyFixesPreservesLeadingComment(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "foo.sql")
+	comment := "-- skeema:lint-ignore no-pk\n"
+	stmtText := comment + "CREATE TABLE foo (id int);"
+	if err := os.WriteFile(path, []byte(stmtText+"\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	stmt := &fs.Statement{File: path, Offset: 0, Text: stmtText}
+	canonical := "CREATE TABLE `foo` (`id` int)"
+	result := &Result{
+		FormatNotices: []*Annotation{{Statement: stmt, Message: canonical}},
+	}
+
+	var buf bytes.Buffer
+	if err := ApplyFixes(&buf, result, Options{Fix: true}); err != nil {
+		t.Fatalf("Unexpected error from ApplyFixes: %v", err)
+	}
+
+	rewritten, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := comment + canonical + "\n"; string(rewritten) != expected {
+		t.Errorf("Expected rewritten file to equal %q, instead found %q", expected, string(rewritten))
+	}
+}
+
+// TestApplyFixesMultiStatementFixture confirms that fixing one statement in
+// a multi-statement file leaves every other statement -- and the comments
+// and whitespace around them -- byte-for-byte intact, using fs.Statement
+// values produced by the real tokenizer rather than hand-built literals.
+func TestApplyFixesMultiStatementFixture(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "schema.sql")
+	sql := "-- a comment about foo\nCREATE TABLE foo (id int);\n\n" +
+		"CREATE TABLE bar (id int, PRIMARY KEY(id));\n\n" +
+		"-- trailing comment\n"
+	if err := os.WriteFile(path, []byte(sql), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	logicalSchema := parseLogicalSchema(t, path, sql)
+	foo := logicalSchema.Creates[tengo.ObjectKey{Type: tengo.ObjectTypeTable, Name: "foo"}]
+	if foo == nil {
+		t.Fatal("Expected a CREATE statement for foo, instead found none")
+	}
+
+	canonical := "CREATE TABLE `foo` (\n  `id` int\n)"
+	result := &Result{
+		FormatNotices: []*Annotation{{Statement: foo, Message: canonical}},
+	}
+	var buf bytes.Buffer
+	if err := ApplyFixes(&buf, result, Options{Fix: true}); err != nil {
+		t.Fatalf("Unexpected error from ApplyFixes: %v", err)
+	}
+
+	rewritten, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := "-- a comment about foo\n" + canonical + "\n\n" +
+		"CREATE TABLE bar (id int, PRIMARY KEY(id));\n\n" +
+		"-- trailing comment\n"
+	if string(rewritten) != expected {
+		t.Errorf("Expected rewritten file to equal %q, instead found %q", expected, string(rewritten))
+	}
+}
+
+func TestLCSDiff(t *testing.T) {
+	a := []string{"one", "two", "three"}
+	b := []string{"one", "TWO", "three"}
+	ops := lcsDiff(a, b)
+	var kinds []byte
+	for _, op := range ops {
+		kinds = append(kinds, op.kind)
+	}
+	expected := []byte{' ', '-', '+', ' '}
+	if len(kinds) != len(expected) {
+		t.Fatalf("Expected %d ops, instead got %d: %+v", len(expected), len(kinds), ops)
+	}
+	for i := range expected {
+		if kinds[i] != expected[i] {
+			t.Errorf("Op %d: expected kind %q, found %q", i, expected[i], kinds[i])
+		}
+	}
+}
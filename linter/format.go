@@ -0,0 +1,212 @@
+package linter
+
+import (
+	"encoding/json"
+	"io"
+	"path/filepath"
+)
+
+// OutputFormat selects how a Result is rendered by WriteJSON/WriteSARIF.
+type OutputFormat string
+
+// Constants enumerating supported output formats.
+const (
+	FormatText  OutputFormat = "text"
+	FormatJSON  OutputFormat = "json"
+	FormatSARIF OutputFormat = "sarif"
+)
+
+// location computes the file, line, and column that a should be reported
+// against. File is expressed relative to baseDir when possible.
+func (a *Annotation) location(baseDir string) (file string, line, col int) {
+	file = a.Statement.File
+	if baseDir != "" {
+		if rel, err := filepath.Rel(baseDir, file); err == nil {
+			file = rel
+		}
+	}
+	line = a.Statement.LineNo + a.LineOffset
+	if a.LineOffset == 0 {
+		col = a.Statement.CharNo
+	}
+	return file, line, col
+}
+
+// jsonAnnotation is the JSON representation of a single Annotation.
+type jsonAnnotation struct {
+	File     string `json:"file"`
+	Line     int    `json:"line,omitempty"`
+	Column   int    `json:"column,omitempty"`
+	Severity string `json:"severity"`
+	Rule     string `json:"rule"`
+	Summary  string `json:"summary"`
+	Message  string `json:"message"`
+}
+
+// jsonOutput is the top-level JSON representation of a Result.
+type jsonOutput struct {
+	Annotations []jsonAnnotation `json:"annotations"`
+	Exceptions  []string         `json:"exceptions,omitempty"`
+}
+
+func (r *Result) jsonAnnotations(baseDir string) []jsonAnnotation {
+	var out []jsonAnnotation
+	add := func(anns []*Annotation, severity string) {
+		for _, ann := range anns {
+			file, line, col := ann.location(baseDir)
+			out = append(out, jsonAnnotation{
+				File:     file,
+				Line:     line,
+				Column:   col,
+				Severity: severity,
+				Rule:     ann.ProblemName,
+				Summary:  ann.Summary,
+				Message:  ann.Message,
+			})
+		}
+	}
+	add(r.Errors, "error")
+	add(r.Warnings, "warning")
+	add(r.FormatNotices, "notice")
+	return out
+}
+
+// WriteJSON serializes r as JSON to w. File paths in the output are
+// expressed relative to baseDir when possible, falling back to the
+// statement's original path otherwise.
+func (r *Result) WriteJSON(w io.Writer, baseDir string) error {
+	out := jsonOutput{Annotations: r.jsonAnnotations(baseDir)}
+	for _, exc := range r.Exceptions {
+		out.Exceptions = append(out.Exceptions, exc.Error())
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(out)
+}
+
+// sarifLog is the top-level SARIF 2.1.0 document.
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID                   string          `json:"id"`
+	ShortDescription     sarifMessage    `json:"shortDescription"`
+	DefaultConfiguration sarifRuleConfig `json:"defaultConfiguration"`
+}
+
+type sarifRuleConfig struct {
+	Level string `json:"level"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifResult struct {
+	RuleID    string          `json:"ruleId"`
+	Level     string          `json:"level"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           sarifRegion           `json:"region"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type sarifRegion struct {
+	StartLine   int `json:"startLine"`
+	StartColumn int `json:"startColumn,omitempty"`
+}
+
+// WriteSARIF serializes r as a SARIF 2.1.0 log to w, suitable for ingestion
+// by CI systems such as GitHub code scanning, GitLab, or SonarQube. File
+// paths are expressed relative to baseDir when possible.
+func (r *Result) WriteSARIF(w io.Writer, baseDir string) error {
+	var ruleOrder []string
+	ruleSeen := make(map[string]bool)
+	ruleLevel := make(map[string]string)
+	ruleDesc := make(map[string]string)
+	var results []sarifResult
+
+	addResults := func(anns []*Annotation, level string) {
+		for _, ann := range anns {
+			rule := ann.ProblemName
+			if rule == "" {
+				rule = "unknown"
+			}
+			if !ruleSeen[rule] {
+				ruleSeen[rule] = true
+				ruleOrder = append(ruleOrder, rule)
+				ruleLevel[rule] = level
+				ruleDesc[rule] = ann.Summary
+			}
+			file, line, col := ann.location(baseDir)
+			results = append(results, sarifResult{
+				RuleID:  rule,
+				Level:   level,
+				Message: sarifMessage{Text: ann.Message},
+				Locations: []sarifLocation{{
+					PhysicalLocation: sarifPhysicalLocation{
+						ArtifactLocation: sarifArtifactLocation{URI: filepath.ToSlash(file)},
+						Region:           sarifRegion{StartLine: line, StartColumn: col},
+					},
+				}},
+			})
+		}
+	}
+	addResults(r.Errors, "error")
+	addResults(r.Warnings, "warning")
+	addResults(r.FormatNotices, "note")
+
+	rules := make([]sarifRule, len(ruleOrder))
+	for i, rule := range ruleOrder {
+		rules[i] = sarifRule{
+			ID:                   rule,
+			ShortDescription:     sarifMessage{Text: ruleDesc[rule]},
+			DefaultConfiguration: sarifRuleConfig{Level: ruleLevel[rule]},
+		}
+	}
+
+	doc := sarifLog{
+		Schema:  "https://raw.githubusercontent.com/oasis-tcs/sarif-spec/master/Schemata/sarif-schema-2.1.0.json",
+		Version: "2.1.0",
+		Runs: []sarifRun{{
+			Tool: sarifTool{Driver: sarifDriver{
+				Name:           "skeema",
+				InformationURI: "https://www.skeema.io",
+				Rules:          rules,
+			}},
+			Results: results,
+		}},
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(doc)
+}
@@ -0,0 +1,98 @@
+package linter
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/skeema/skeema/fs"
+)
+
+func TestValidateFormat(t *testing.T) {
+	for _, f := range []OutputFormat{FormatText, FormatJSON, FormatSARIF} {
+		if err := validateFormat(f); err != nil {
+			t.Errorf("Unexpected error validating format %q: %v", f, err)
+		}
+	}
+	if err := validateFormat(OutputFormat("yaml")); err == nil {
+		t.Error("Expected error validating unsupported format, instead found nil")
+	} else if _, ok := err.(ConfigError); !ok {
+		t.Errorf("Expected ConfigError, instead got %T", err)
+	}
+}
+
+func sampleResult() *Result {
+	stmt := &fs.Statement{
+		File:   "schema/foo.sql",
+		LineNo: 3,
+		CharNo: 1,
+		Text:   "CREATE TABLE foo (id int);",
+	}
+	return &Result{
+		Errors: []*Annotation{{
+			Statement:   stmt,
+			Summary:     "no primary key",
+			Message:     "Table foo has no primary key",
+			ProblemName: "no-pk",
+		}},
+		Warnings: []*Annotation{{
+			Statement:   stmt,
+			Summary:     "disallowed charset",
+			Message:     "Table foo uses charset latin1",
+			ProblemName: "bad-charset",
+		}},
+	}
+}
+
+func TestResultWriteJSON(t *testing.T) {
+	var buf bytes.Buffer
+	if err := sampleResult().WriteJSON(&buf, "schema"); err != nil {
+		t.Fatalf("Unexpected error from WriteJSON: %v", err)
+	}
+	var out jsonOutput
+	if err := json.Unmarshal(buf.Bytes(), &out); err != nil {
+		t.Fatalf("WriteJSON produced invalid JSON: %v", err)
+	}
+	if len(out.Annotations) != 2 {
+		t.Fatalf("Expected 2 annotations, instead found %d", len(out.Annotations))
+	}
+	for _, ann := range out.Annotations {
+		if ann.File != "foo.sql" {
+			t.Errorf("Expected file relative to baseDir (foo.sql), instead found %q", ann.File)
+		}
+	}
+}
+
+func TestResultWriteSARIF(t *testing.T) {
+	var buf bytes.Buffer
+	if err := sampleResult().WriteSARIF(&buf, "schema"); err != nil {
+		t.Fatalf("Unexpected error from WriteSARIF: %v", err)
+	}
+	var doc sarifLog
+	if err := json.Unmarshal(buf.Bytes(), &doc); err != nil {
+		t.Fatalf("WriteSARIF produced invalid JSON: %v", err)
+	}
+	if doc.Version != "2.1.0" {
+		t.Errorf("Expected SARIF version 2.1.0, instead found %q", doc.Version)
+	}
+	if len(doc.Runs) != 1 || len(doc.Runs[0].Results) != 2 {
+		t.Fatalf("Expected 1 run with 2 results, instead found %+v", doc.Runs)
+	}
+	rules := doc.Runs[0].Tool.Driver.Rules
+	var sawError, sawWarning bool
+	for _, rule := range rules {
+		if rule.ID == "no-pk" && rule.DefaultConfiguration.Level == "error" {
+			sawError = true
+		}
+		if rule.ID == "bad-charset" && rule.DefaultConfiguration.Level == "warning" {
+			sawWarning = true
+		}
+	}
+	if !sawError || !sawWarning {
+		t.Errorf("Expected rules with error and warning levels, instead found %+v", rules)
+	}
+	if !strings.HasSuffix(doc.Runs[0].Results[0].Locations[0].PhysicalLocation.ArtifactLocation.URI, "foo.sql") {
+		t.Errorf("Expected artifact URI to end with foo.sql, instead found %q", doc.Runs[0].Results[0].Locations[0].PhysicalLocation.ArtifactLocation.URI)
+	}
+}
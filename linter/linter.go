@@ -3,6 +3,7 @@ package linter
 
 import (
 	"fmt"
+	"regexp"
 
 	"github.com/skeema/skeema/fs"
 	"github.com/skeema/skeema/workspace"
@@ -12,10 +13,11 @@ import (
 // Annotation is an error, warning, or notice from linting a single SQL
 // statement.
 type Annotation struct {
-	Statement  *fs.Statement
-	LineOffset int
-	Summary    string
-	Message    string
+	Statement   *fs.Statement
+	LineOffset  int
+	Summary     string
+	Message     string
+	ProblemName string // Name of the linter problem that generated this annotation, e.g. "no-pk"
 }
 
 // MessageWithLocation prepends statement location information to a.Message,
@@ -96,51 +98,104 @@ func LintDir(dir *fs.Dir, wsOpts workspace.Options) *Result {
 			}
 		}
 
-		// Convert the logical schema from the filesystem into a real schema, using a
-		// workspace
-		schema, statementErrors, err := workspace.ExecLogicalSchema(logicalSchema, wsOpts)
-		if err != nil {
-			result.Exceptions = append(result.Exceptions, fmt.Errorf("Skipping schema in %s due to error: %s", dir.RelPath(), err))
+		result.Merge(lintLogicalSchema(dir.RelPath(), logicalSchema, opts, wsOpts, ignoreTable))
+	}
+	return result
+}
+
+// lintLogicalSchema lints a single fs.LogicalSchema against wsOpts and opts,
+// returning its own standalone Result. This is the unit of work shared by
+// LintDir, which invokes it once per logical schema found while walking a
+// directory tree, and LintFiles/LintReader, which synthesize a single
+// ephemeral logical schema from specific files or stdin. relPath is used
+// only for debug logging and exception messages; ignoreTable may be nil.
+func lintLogicalSchema(relPath string, logicalSchema *fs.LogicalSchema, opts Options, wsOpts workspace.Options, ignoreTable *regexp.Regexp) *Result {
+	result := &Result{}
+
+	// Convert the logical schema from the filesystem into a real schema, using a
+	// workspace
+	schema, statementErrors, err := workspace.ExecLogicalSchema(logicalSchema, wsOpts)
+	if err != nil {
+		result.Exceptions = append(result.Exceptions, fmt.Errorf("Skipping schema in %s due to error: %s", relPath, err))
+		return result
+	}
+	for _, stmtErr := range statementErrors {
+		if stmtErr.ObjectType == tengo.ObjectTypeTable && ignoreTable != nil && ignoreTable.MatchString(stmtErr.ObjectName) {
+			result.DebugLogs = append(result.DebugLogs, fmt.Sprintf("Skipping %s because ignore-table='%s'", stmtErr.ObjectKey(), ignoreTable))
 			continue
 		}
-		for _, stmtErr := range statementErrors {
-			if stmtErr.ObjectType == tengo.ObjectTypeTable && ignoreTable != nil && ignoreTable.MatchString(stmtErr.ObjectName) {
-				result.DebugLogs = append(result.DebugLogs, fmt.Sprintf("Skipping %s because ignore-table='%s'", stmtErr.ObjectKey(), ignoreTable))
-				continue
+		result.Errors = append(result.Errors, &Annotation{
+			Statement:   stmtErr.Statement,
+			Summary:     "SQL statement returned an error",
+			Message:     stmtErr.Err.Error(),
+			ProblemName: "sql-error",
+		})
+	}
+
+	var fileDirs map[string]*directiveSet
+	if !opts.NoInlineDirectives {
+		fileDirs = fileDirectives(logicalSchema)
+	}
+	file := func(ann *Annotation, severity Severity) {
+		if !opts.NoInlineDirectives {
+			ds := directivesForStatement(ann.Statement, fileDirs)
+			if ds != nil && ds.suppressed[ann.ProblemName] {
+				result.DebugLogs = append(result.DebugLogs, fmt.Sprintf("Suppressing %s annotation for %s due to inline lint directive", ann.ProblemName, ann.MessageWithLocation()))
+				return
+			}
+			if ds != nil {
+				if override, ok := ds.overrides[ann.ProblemName]; ok {
+					severity = override
+				}
 			}
-			result.Errors = append(result.Errors, &Annotation{
-				Statement: stmtErr.Statement,
-				Summary:   "SQL statement returned an error",
-				Message:   stmtErr.Err.Error(),
-			})
 		}
+		if severity == SeverityWarning {
+			result.Warnings = append(result.Warnings, ann)
+		} else {
+			result.Errors = append(result.Errors, ann)
+		}
+	}
 
-		for problemName, severity := range opts.ProblemSeverity {
-			annotations := problems[problemName](schema, logicalSchema, opts)
-			if severity == SeverityWarning {
-				result.Warnings = append(result.Warnings, annotations...)
-			} else {
-				result.Errors = append(result.Errors, annotations...)
-			}
+	for problemName, severity := range opts.ProblemSeverity {
+		annotations := problems[problemName](schema, logicalSchema, opts)
+		for _, ann := range annotations {
+			ann.ProblemName = problemName
+			file(ann, severity)
 		}
+	}
 
-		// Compare each canonical CREATE in the real schema to each CREATE statement
-		// from the filesystem. In cases where they differ, emit a notice to reformat
-		// the file using the canonical version from the DB.
-		for key, instCreateText := range schema.ObjectDefinitions() {
-			if key.Type == tengo.ObjectTypeTable && ignoreTable != nil && ignoreTable.MatchString(key.Name) {
-				result.DebugLogs = append(result.DebugLogs, fmt.Sprintf("Skipping %s because ignore-table='%s'", key, ignoreTable))
-				continue
-			}
-			fsStmt := logicalSchema.Creates[key]
-			fsBody, fsSuffix := fsStmt.SplitTextBody()
-			if instCreateText != fsBody {
-				result.FormatNotices = append(result.FormatNotices, &Annotation{
-					Statement: fsStmt,
-					Summary:   "SQL statement should be reformatted",
-					Message:   fmt.Sprintf("%s%s", instCreateText, fsSuffix),
-				})
+	for _, checker := range opts.ExternalCheckers {
+		findings, err := checker.Run(schema, logicalSchema)
+		if err != nil {
+			result.Exceptions = append(result.Exceptions, err)
+			continue
+		}
+		for _, finding := range findings {
+			severity := finding.DefaultSeverity
+			if override, ok := opts.ProblemSeverity[finding.Annotation.ProblemName]; ok {
+				severity = override
 			}
+			file(finding.Annotation, severity)
+		}
+	}
+
+	// Compare each canonical CREATE in the real schema to each CREATE statement
+	// from the filesystem. In cases where they differ, emit a notice to reformat
+	// the file using the canonical version from the DB.
+	for key, instCreateText := range schema.ObjectDefinitions() {
+		if key.Type == tengo.ObjectTypeTable && ignoreTable != nil && ignoreTable.MatchString(key.Name) {
+			result.DebugLogs = append(result.DebugLogs, fmt.Sprintf("Skipping %s because ignore-table='%s'", key, ignoreTable))
+			continue
+		}
+		fsStmt := logicalSchema.Creates[key]
+		fsBody, fsSuffix := fsStmt.SplitTextBody()
+		if instCreateText != fsBody {
+			result.FormatNotices = append(result.FormatNotices, &Annotation{
+				Statement:   fsStmt,
+				Summary:     "SQL statement should be reformatted",
+				Message:     fmt.Sprintf("%s%s", instCreateText, fsSuffix),
+				ProblemName: "format",
+			})
 		}
 	}
 	return result
@@ -0,0 +1,203 @@
+package linter
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/skeema/skeema/fs"
+	"github.com/skeema/skeema/linter/plugin"
+	"github.com/skeema/tengo"
+)
+
+// externalCheckerTimeout bounds how long a single invocation of an external
+// checker (subprocess or TCP) is allowed to run before it is aborted,
+// ensuring a hung or malicious checker cannot block a lint run indefinitely.
+// It is a var rather than a const so tests can shrink it.
+var externalCheckerTimeout = 30 * time.Second
+
+// ExternalChecker configures an external process (or TCP service) that
+// supplies additional lint rules beyond the built-in problems map, without
+// requiring skeema itself to be recompiled.
+type ExternalChecker struct {
+	Name   string
+	Target string // path to a local executable, or a tcp://host:port address
+}
+
+// ParseExternalCheckers converts raw `plugin` option values, each of the
+// form "name:target", into ExternalCheckers.
+func ParseExternalCheckers(raw []string) ([]ExternalChecker, error) {
+	if len(raw) == 0 {
+		return nil, nil
+	}
+	checkers := make([]ExternalChecker, 0, len(raw))
+	for _, entry := range raw {
+		name, target, found := strings.Cut(entry, ":")
+		if !found || name == "" || target == "" {
+			return nil, ConfigError(fmt.Sprintf("Option plugin entry %q must be of the form name:target", entry))
+		}
+		checkers = append(checkers, ExternalChecker{Name: name, Target: target})
+	}
+	return checkers, nil
+}
+
+// pluginFinding pairs an Annotation converted from a plugin.Finding with the
+// severity the plugin itself requested, prior to any ProblemSeverity
+// override.
+type pluginFinding struct {
+	Annotation      *Annotation
+	DefaultSeverity Severity
+}
+
+// Run hands schema to the checker over its configured target and converts
+// the findings it returns into Annotations.
+func (ec ExternalChecker) Run(schema *tengo.Schema, logicalSchema *fs.LogicalSchema) ([]pluginFinding, error) {
+	req := plugin.Request{Schema: schemaToPluginSchema(schema, logicalSchema)}
+	payload, err := json.Marshal(req)
+	if err != nil {
+		return nil, fmt.Errorf("plugin %s: unable to marshal request: %w", ec.Name, err)
+	}
+
+	output, err := ec.exec(payload)
+	if err != nil {
+		return nil, fmt.Errorf("plugin %s: %w", ec.Name, err)
+	}
+
+	var findings []plugin.Finding
+	if err := json.Unmarshal(output, &findings); err != nil {
+		return nil, fmt.Errorf("plugin %s: unable to parse response: %w", ec.Name, err)
+	}
+
+	stmtsByName := statementsByObjectName(logicalSchema)
+	results := make([]pluginFinding, 0, len(findings))
+	for _, finding := range findings {
+		stmt, ok := stmtsByName[finding.ObjectName]
+		if !ok {
+			continue
+		}
+		severity := SeverityError
+		if strings.EqualFold(finding.Severity, "warning") {
+			severity = SeverityWarning
+		}
+		results = append(results, pluginFinding{
+			Annotation: &Annotation{
+				Statement:   stmt,
+				LineOffset:  finding.LineOffset,
+				Summary:     finding.Summary,
+				Message:     finding.Message,
+				ProblemName: strings.ToLower(finding.RuleName),
+			},
+			DefaultSeverity: severity,
+		})
+	}
+	return results, nil
+}
+
+// exec dispatches payload to the checker's target -- a local subprocess's
+// stdin, or a TCP connection -- and returns its response. Both paths are
+// bounded by externalCheckerTimeout, so a hung or malicious checker cannot
+// block a lint run indefinitely.
+func (ec ExternalChecker) exec(payload []byte) ([]byte, error) {
+	if strings.HasPrefix(ec.Target, "tcp://") {
+		addr := strings.TrimPrefix(ec.Target, "tcp://")
+		conn, err := net.DialTimeout("tcp", addr, externalCheckerTimeout)
+		if err != nil {
+			return nil, err
+		}
+		defer conn.Close()
+		if err := conn.SetDeadline(time.Now().Add(externalCheckerTimeout)); err != nil {
+			return nil, err
+		}
+		if _, err := conn.Write(payload); err != nil {
+			return nil, err
+		}
+		if halfCloser, ok := conn.(interface{ CloseWrite() error }); ok {
+			halfCloser.CloseWrite()
+		}
+		var buf bytes.Buffer
+		if _, err := buf.ReadFrom(conn); err != nil {
+			return nil, err
+		}
+		return buf.Bytes(), nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), externalCheckerTimeout)
+	defer cancel()
+	cmd := exec.CommandContext(ctx, ec.Target)
+	cmd.Stdin = bytes.NewReader(payload)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if ctx.Err() == context.DeadlineExceeded {
+			return nil, fmt.Errorf("timed out after %s: %s", externalCheckerTimeout, stderr.String())
+		}
+		return nil, fmt.Errorf("%w: %s", err, stderr.String())
+	}
+	return stdout.Bytes(), nil
+}
+
+// statementsByObjectName indexes logicalSchema's CREATE statements by their
+// object name, for mapping plugin findings back to file locations.
+func statementsByObjectName(logicalSchema *fs.LogicalSchema) map[string]*fs.Statement {
+	byName := make(map[string]*fs.Statement, len(logicalSchema.Creates))
+	for key, stmt := range logicalSchema.Creates {
+		byName[key.Name] = stmt
+	}
+	return byName
+}
+
+// schemaToPluginSchema converts schema into the simplified, JSON-friendly
+// representation sent to external checkers.
+func schemaToPluginSchema(schema *tengo.Schema, logicalSchema *fs.LogicalSchema) plugin.Schema {
+	out := plugin.Schema{Name: schema.Name}
+	for _, table := range schema.Tables {
+		pt := plugin.Table{
+			Name:    table.Name,
+			Charset: table.CharSet,
+			Engine:  table.Engine,
+		}
+		if stmt, ok := logicalSchema.Creates[tengo.ObjectKey{Type: tengo.ObjectTypeTable, Name: table.Name}]; ok {
+			pt.File = stmt.File
+			pt.LineNo = stmt.LineNo
+		}
+		for _, col := range table.Columns {
+			pt.Columns = append(pt.Columns, plugin.Column{
+				Name:     col.Name,
+				Type:     col.TypeInDB,
+				Nullable: col.Nullable,
+				Default:  col.Default,
+			})
+		}
+		for _, idx := range table.SecondaryIndexes {
+			pt.Indexes = append(pt.Indexes, plugin.Index{
+				Name:    idx.Name,
+				Columns: idx.ColumnNames(),
+				Unique:  idx.Unique,
+			})
+		}
+		if table.PrimaryKey != nil {
+			pt.Indexes = append(pt.Indexes, plugin.Index{
+				Name:    table.PrimaryKey.Name,
+				Columns: table.PrimaryKey.ColumnNames(),
+				Unique:  true,
+				Primary: true,
+			})
+		}
+		for _, fk := range table.ForeignKeys {
+			pt.ForeignKeys = append(pt.ForeignKeys, plugin.ForeignKey{
+				Name:              fk.Name,
+				Columns:           fk.ColumnNames(),
+				ReferencedTable:   fk.ReferencedTableName,
+				ReferencedColumns: fk.ReferencedColumnNames(),
+			})
+		}
+		out.Tables = append(out.Tables, pt)
+	}
+	return out
+}
@@ -0,0 +1,65 @@
+// Package plugin defines the request/response contract between skeema's
+// linter and external checker processes, so that third parties can
+// implement additional lint rules in any language without recompiling
+// skeema. A checker receives a Request as JSON on stdin (or over a TCP
+// connection) and writes back a JSON array of Finding on stdout (or over
+// that same connection).
+package plugin
+
+// Request is the payload handed to an external checker once per schema
+// being linted.
+type Request struct {
+	Schema Schema `json:"schema"`
+}
+
+// Schema is a simplified, JSON-friendly view of a resolved schema.
+type Schema struct {
+	Name   string  `json:"name"`
+	Tables []Table `json:"tables"`
+}
+
+// Table describes a single table's shape and filesystem location.
+type Table struct {
+	Name        string       `json:"name"`
+	Columns     []Column     `json:"columns"`
+	Indexes     []Index      `json:"indexes"`
+	ForeignKeys []ForeignKey `json:"foreignKeys"`
+	Charset     string       `json:"charset"`
+	Engine      string       `json:"engine"`
+	File        string       `json:"file"`
+	LineNo      int          `json:"lineNo"`
+}
+
+// Column describes a single column of a Table.
+type Column struct {
+	Name     string `json:"name"`
+	Type     string `json:"type"`
+	Nullable bool   `json:"nullable"`
+	Default  string `json:"default,omitempty"`
+}
+
+// Index describes a single index (including the primary key) of a Table.
+type Index struct {
+	Name    string   `json:"name"`
+	Columns []string `json:"columns"`
+	Unique  bool     `json:"unique"`
+	Primary bool     `json:"primary"`
+}
+
+// ForeignKey describes a single foreign key constraint of a Table.
+type ForeignKey struct {
+	Name              string   `json:"name"`
+	Columns           []string `json:"columns"`
+	ReferencedTable   string   `json:"referencedTable"`
+	ReferencedColumns []string `json:"referencedColumns"`
+}
+
+// Finding is a single lint result reported by an external checker.
+type Finding struct {
+	RuleName   string `json:"ruleName"`
+	Severity   string `json:"severity"` // "error" or "warning"
+	ObjectName string `json:"objectName"`
+	LineOffset int    `json:"lineOffset"`
+	Summary    string `json:"summary"`
+	Message    string `json:"message"`
+}
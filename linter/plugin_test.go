@@ -0,0 +1,88 @@
+package linter
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/skeema/skeema/fs"
+	"github.com/skeema/tengo"
+)
+
+func TestParseExternalCheckers(t *testing.T) {
+	if checkers, err := ParseExternalCheckers(nil); err != nil || checkers != nil {
+		t.Errorf("Expected nil, nil for empty input, instead found %+v, %v", checkers, err)
+	}
+
+	checkers, err := ParseExternalCheckers([]string{"fake:./checker.sh", "other:tcp://localhost:1234"})
+	if err != nil {
+		t.Fatalf("Unexpected error from ParseExternalCheckers: %v", err)
+	}
+	expected := []ExternalChecker{
+		{Name: "fake", Target: "./checker.sh"},
+		{Name: "other", Target: "tcp://localhost:1234"},
+	}
+	if len(checkers) != len(expected) {
+		t.Fatalf("Expected %d checkers, instead found %d", len(expected), len(checkers))
+	}
+	for n := range expected {
+		if checkers[n] != expected[n] {
+			t.Errorf("Checker %d: expected %+v, instead found %+v", n, expected[n], checkers[n])
+		}
+	}
+
+	for _, bad := range []string{"missingtarget:", ":missingname", "noseparator"} {
+		if _, err := ParseExternalCheckers([]string{bad}); err == nil {
+			t.Errorf("Expected error parsing %q, instead found nil", bad)
+		} else if _, ok := err.(ConfigError); !ok {
+			t.Errorf("Expected ConfigError parsing %q, instead got %T", bad, err)
+		}
+	}
+}
+
+// TestExternalCheckerRunLowercasesRuleName confirms that Run normalizes a
+// plugin-reported RuleName to lowercase before storing it as the
+// Annotation's ProblemName, the same as every other path (--warnings,
+// --errors, skeema:lint-* directives) that uses a problem name as a map key.
+// It runs Run against a real subprocess, not a mock, to exercise the actual
+// exec/JSON round-trip.
+func TestExternalCheckerRunLowercasesRuleName(t *testing.T) {
+	dir := t.TempDir()
+	script := filepath.Join(dir, "checker.sh")
+	body := "#!/bin/sh\ncat <<'EOF'\n[{\"ruleName\":\"No-PK\",\"severity\":\"error\",\"objectName\":\"foo\",\"summary\":\"s\",\"message\":\"m\"}]\nEOF\n"
+	if err := os.WriteFile(script, []byte(body), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	stmt := &fs.Statement{File: "foo.sql", Text: "CREATE TABLE foo (id int);"}
+	logicalSchema := &fs.LogicalSchema{
+		Creates: map[tengo.ObjectKey]*fs.Statement{
+			{Type: tengo.ObjectTypeTable, Name: "foo"}: stmt,
+		},
+	}
+	ec := ExternalChecker{Name: "fake", Target: script}
+	findings, err := ec.Run(&tengo.Schema{Name: "test"}, logicalSchema)
+	if err != nil {
+		t.Fatalf("Unexpected error from Run: %v", err)
+	}
+	if len(findings) != 1 {
+		t.Fatalf("Expected 1 finding, instead found %d", len(findings))
+	}
+	if findings[0].Annotation.ProblemName != "no-pk" {
+		t.Errorf("Expected ProblemName to be lowercased to \"no-pk\", instead found %q", findings[0].Annotation.ProblemName)
+	}
+}
+
+func TestExternalCheckerExecSubprocessTimeout(t *testing.T) {
+	ec := ExternalChecker{Name: "sleepy", Target: "sleep"}
+	orig := externalCheckerTimeout
+	externalCheckerTimeout = 0
+	defer func() { externalCheckerTimeout = orig }()
+
+	if _, err := ec.exec(nil); err == nil {
+		t.Error("Expected error from exec with an immediately-expired timeout, instead found nil")
+	} else if !strings.Contains(err.Error(), "timed out") && !strings.Contains(err.Error(), "context deadline exceeded") {
+		t.Errorf("Expected a timeout-related error, instead found: %v", err)
+	}
+}